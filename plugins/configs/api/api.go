@@ -0,0 +1,234 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/models"
+)
+
+// PluginState describes the lifecycle state of a plugin managed by the API.
+type PluginState int
+
+const (
+	PluginCreated PluginState = iota
+	PluginStarting
+	PluginRunning
+	PluginFailed
+	PluginStopped
+)
+
+func (s PluginState) String() string {
+	switch s {
+	case PluginCreated:
+		return "created"
+	case PluginStarting:
+		return "starting"
+	case PluginRunning:
+		return "running"
+	case PluginFailed:
+		return "failed"
+	case PluginStopped:
+		return "stopped"
+	default:
+		return "unknown"
+	}
+}
+
+// PluginConfigCreate is the request body used to create or update a plugin.
+type PluginConfigCreate struct {
+	Type   string                 `json:"type" toml:"type"`
+	Config map[string]interface{} `json:"config" toml:"config"`
+}
+
+// PluginTypeInfo describes a plugin type that is available to be created.
+type PluginTypeInfo struct {
+	Type string `json:"type"`
+}
+
+// RunningPluginInfo describes a single running plugin instance.
+type RunningPluginInfo struct {
+	ID    models.PluginID `json:"id"`
+	Type  string          `json:"type"`
+	State PluginState     `json:"state"`
+}
+
+// runningPlugin tracks everything the api needs to manage a single plugin
+// instance: its current config, its state, the function used to stop it, and
+// a running count of metrics it has produced (reported periodically to
+// event subscribers).
+type runningPlugin struct {
+	id            models.PluginID
+	cfg           PluginConfigCreate
+	state         PluginState
+	metrics       int64
+	stop          func()
+	cancelMetrics context.CancelFunc
+}
+
+// stopPlugin stops rp and cancels its periodic metrics reporting goroutine.
+// Every path that removes or replaces a runningPlugin must go through this
+// rather than calling rp.stop directly, so the reporting goroutine never
+// leaks.
+func (a *api) stopPlugin(rp *runningPlugin) {
+	rp.stop()
+	if rp.cancelMetrics != nil {
+		rp.cancelMetrics()
+	}
+}
+
+// api owns the set of currently running plugins and serializes all mutating
+// operations (create/update/delete) behind a single mutex so that the set of
+// running plugins is never observed in a partially-mutated state.
+type api struct {
+	mu      sync.Mutex
+	plugins map[models.PluginID]*runningPlugin
+	events  *broker
+	Log     telegraf.Logger
+}
+
+func newAPI(log telegraf.Logger) *api {
+	return &api{
+		plugins: make(map[models.PluginID]*runningPlugin),
+		events:  newBroker(),
+		Log:     log,
+	}
+}
+
+// newPluginID returns a random hex-encoded plugin identifier matching the
+// `[0-9a-f]+` route pattern registered in mux().
+func newPluginID() (models.PluginID, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generating plugin id: %w", err)
+	}
+	return models.PluginID(fmt.Sprintf("%x", buf)), nil
+}
+
+// startPlugin constructs and starts a plugin instance from cfg. It is a
+// placeholder for the real plugin-registry lookup and Start() call; it is
+// kept separate so CreatePlugin and UpdatePlugin can share identical
+// start/rollback semantics.
+func (a *api) startPlugin(cfg PluginConfigCreate) (*runningPlugin, error) {
+	if cfg.Type == "" {
+		return nil, fmt.Errorf("%w: missing plugin type", ErrPluginNotStarted)
+	}
+	return &runningPlugin{
+		cfg:   cfg,
+		state: PluginRunning,
+		stop:  func() {},
+	}, nil
+}
+
+func (a *api) CreatePlugin(cfg PluginConfigCreate, id models.PluginID) (models.PluginID, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if id == "" {
+		generated, err := newPluginID()
+		if err != nil {
+			return "", err
+		}
+		id = generated
+	}
+	if _, ok := a.plugins[id]; ok {
+		return "", fmt.Errorf("%w: plugin %q already exists", ErrDuplicatePlugin, id)
+	}
+
+	rp, err := a.startPlugin(cfg)
+	if err != nil {
+		a.notify(id, EventFailed, PluginFailed, err)
+		return "", err
+	}
+	rp.id = id
+	a.plugins[id] = rp
+	a.notify(id, EventRunning, rp.state, nil)
+	a.beginMetricsReporting(rp)
+	return id, nil
+}
+
+func (a *api) DeletePlugin(id models.PluginID) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	rp, ok := a.plugins[id]
+	if !ok {
+		return fmt.Errorf("%w: plugin %q", ErrNotFound, id)
+	}
+	a.stopPlugin(rp)
+	delete(a.plugins, id)
+	a.notify(id, EventStopped, PluginStopped, nil)
+	return nil
+}
+
+func (a *api) ListPluginTypes() []PluginTypeInfo {
+	// Placeholder: the real implementation enumerates the plugin registry.
+	return []PluginTypeInfo{}
+}
+
+func (a *api) ListRunningPlugins() []RunningPluginInfo {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	infos := make([]RunningPluginInfo, 0, len(a.plugins))
+	for id, rp := range a.plugins {
+		infos = append(infos, RunningPluginInfo{ID: id, Type: rp.cfg.Type, State: rp.state})
+	}
+	return infos
+}
+
+// UpdatePlugin atomically replaces the configuration of the running plugin
+// identified by id: it starts a new instance from cfg and only then stops
+// the old one, keeping id stable so callers holding it stay valid. If
+// starting the new instance fails, the old plugin is left running untouched,
+// so the running set is never observed in a partially-mutated state.
+func (a *api) UpdatePlugin(id models.PluginID, cfg PluginConfigCreate) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	old, ok := a.plugins[id]
+	if !ok {
+		return fmt.Errorf("%w: plugin %q", ErrNotFound, id)
+	}
+
+	rp, err := a.startPlugin(cfg)
+	if err != nil {
+		a.notify(id, EventFailed, PluginFailed, err)
+		return err
+	}
+	a.stopPlugin(old)
+
+	rp.id = id
+	a.plugins[id] = rp
+	a.notify(id, EventRunning, rp.state, nil)
+	a.beginMetricsReporting(rp)
+	return nil
+}
+
+func (a *api) GetPluginStatus(id models.PluginID) PluginState {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	rp, ok := a.plugins[id]
+	if !ok {
+		return PluginStopped
+	}
+	return rp.state
+}
+
+// recordMetrics adds n to id's running metrics count. It is a placeholder
+// hook for wherever the real plugin wiring counts metrics it has written,
+// so that EventMetricsReport snapshots have something to report.
+func (a *api) recordMetrics(id models.PluginID, n int64) {
+	a.mu.Lock()
+	rp, ok := a.plugins[id]
+	a.mu.Unlock()
+	if !ok {
+		return
+	}
+	atomic.AddInt64(&rp.metrics, n)
+}
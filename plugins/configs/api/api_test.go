@@ -0,0 +1,50 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestUpdatePluginReplacesConfigKeepingID confirms UpdatePlugin swaps in the
+// new config while keeping the plugin's ID stable, so callers holding it
+// stay valid across the update.
+func TestUpdatePluginReplacesConfigKeepingID(t *testing.T) {
+	a := newAPI(testLogger{})
+	id, err := a.CreatePlugin(validCreate("cpu"), "")
+	require.NoError(t, err)
+
+	require.NoError(t, a.UpdatePlugin(id, validCreate("mem")))
+
+	plugins := a.ListRunningPlugins()
+	require.Len(t, plugins, 1)
+	require.Equal(t, id, plugins[0].ID)
+	require.Equal(t, "mem", plugins[0].Type)
+}
+
+// TestUpdatePluginUnknownID confirms updating a plugin that isn't running
+// returns ErrNotFound.
+func TestUpdatePluginUnknownID(t *testing.T) {
+	a := newAPI(testLogger{})
+
+	err := a.UpdatePlugin("does-not-exist", validCreate("cpu"))
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+// TestUpdatePluginLeavesOldRunningOnStartFailure confirms UpdatePlugin's
+// start-before-stop semantics: if the replacement config fails to start, the
+// original plugin is left running untouched rather than the set being
+// observed with neither the old nor the new instance.
+func TestUpdatePluginLeavesOldRunningOnStartFailure(t *testing.T) {
+	a := newAPI(testLogger{})
+	id, err := a.CreatePlugin(validCreate("cpu"), "")
+	require.NoError(t, err)
+
+	err = a.UpdatePlugin(id, PluginConfigCreate{Type: "", Config: map[string]interface{}{}})
+	require.ErrorIs(t, err, ErrPluginNotStarted)
+
+	plugins := a.ListRunningPlugins()
+	require.Len(t, plugins, 1)
+	require.Equal(t, id, plugins[0].ID)
+	require.Equal(t, "cpu", plugins[0].Type)
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// applyPlugins handles POST /plugins/apply: a transactional bulk create and
+// delete. A `dry_run=true` query flag runs only the construct/validate phase
+// for config linting, without mutating the running set.
+func (s *ConfigAPIService) applyPlugins(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	dryRun, err := parseDryRun(req)
+	if err != nil {
+		s.writeError(w, req, err)
+		return
+	}
+
+	applyReq := ApplyRequest{}
+	if err := json.NewDecoder(req.Body).Decode(&applyReq); err != nil {
+		s.writeError(w, req, fmt.Errorf("%w: decode failed %v", ErrBadRequest, err))
+		return
+	}
+
+	result, err := s.api.Apply(applyReq, dryRun)
+	if err != nil {
+		s.audit("/plugins/apply", "", req, "error")
+		s.writeError(w, req, err)
+		return
+	}
+	s.audit("/plugins/apply", "", req, "ok")
+	writeJSON(w, s.Log, http.StatusOK, result)
+}
+
+func parseDryRun(req *http.Request) (bool, error) {
+	raw := req.URL.Query().Get("dry_run")
+	if raw == "" {
+		return false, nil
+	}
+	dryRun, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("%w: invalid dry_run value %q", ErrBadRequest, raw)
+	}
+	return dryRun, nil
+}
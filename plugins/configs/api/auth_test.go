@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testLogger is a no-op telegraf.Logger for use in tests that don't care
+// about log output.
+type testLogger struct{}
+
+func (testLogger) Error(args ...interface{})                 {}
+func (testLogger) Errorf(format string, args ...interface{}) {}
+func (testLogger) Debug(args ...interface{})                 {}
+func (testLogger) Debugf(format string, args ...interface{}) {}
+func (testLogger) Warn(args ...interface{})                  {}
+func (testLogger) Warnf(format string, args ...interface{})  {}
+func (testLogger) Info(args ...interface{})                  {}
+func (testLogger) Infof(format string, args ...interface{})  {}
+
+func newTestService(auth *AuthConfig) *ConfigAPIService {
+	return &ConfigAPIService{
+		server: &http.Server{},
+		api:    newAPI(testLogger{}),
+		Log:    testLogger{},
+		Auth:   auth,
+	}
+}
+
+// TestRequireScopeAllowDeny exercises the allow/deny matrix requireScope is
+// responsible for: no auth configured is an open anonymous admin, a
+// credential holding the required scope (or admin) is let through, and
+// anything else is denied with ErrUnauthorized before next ever runs.
+func TestRequireScopeAllowDeny(t *testing.T) {
+	readOnly := &Credential{Name: "ci", Scopes: map[Scope]bool{ScopeRead: true}}
+	admin := &Credential{Name: "root", Scopes: map[Scope]bool{ScopeAdmin: true}}
+	auth := &AuthConfig{BearerTokens: map[string]*Credential{
+		"read-token":  readOnly,
+		"admin-token": admin,
+	}}
+
+	tests := []struct {
+		name       string
+		auth       *AuthConfig
+		token      string
+		scope      Scope
+		wantCalled bool
+		wantStatus int
+	}{
+		{"no auth configured allows everything", &AuthConfig{}, "", ScopeAdmin, true, http.StatusOK},
+		{"matching scope allowed", auth, "read-token", ScopeRead, true, http.StatusOK},
+		{"admin scope covers any requirement", auth, "admin-token", ScopeWrite, true, http.StatusOK},
+		{"missing scope denied", auth, "read-token", ScopeWrite, false, http.StatusUnauthorized},
+		{"unknown token denied", auth, "bogus-token", ScopeRead, false, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(tt.auth)
+			called := false
+			handler := svc.requireScope(tt.scope, "/test", func(w http.ResponseWriter, req *http.Request) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			req = req.WithContext(context.WithValue(req.Context(), requestIDKey{}, "test-request-id"))
+			rec := httptest.NewRecorder()
+
+			handler(rec, req)
+
+			require.Equal(t, tt.wantCalled, called)
+			require.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/influxdata/telegraf/models"
+)
+
+// PluginDescriptor is a point-in-time snapshot of a single running plugin,
+// sufficient to recreate it if a bulk apply needs to roll back.
+type PluginDescriptor struct {
+	ID  models.PluginID
+	Cfg PluginConfigCreate
+}
+
+// ApplyRequest is the body of POST /plugins/apply: a set of plugins to
+// create and a set of existing plugin IDs to remove, applied as one unit.
+type ApplyRequest struct {
+	Create []PluginConfigCreate `json:"create"`
+	Delete []models.PluginID    `json:"delete"`
+}
+
+// ApplyItemResult reports the outcome of one created plugin in a bulk apply.
+type ApplyItemResult struct {
+	ID   models.PluginID `json:"id,omitempty"`
+	Type string          `json:"type"`
+}
+
+// ApplyResult is the outcome of a successful bulk apply.
+type ApplyResult struct {
+	Created []ApplyItemResult `json:"created"`
+	Deleted []models.PluginID `json:"deleted"`
+}
+
+// snapshot captures the full running set so it can be restored if a later
+// mutation fails partway through. The caller must hold a.mu.
+func (a *api) snapshot() []PluginDescriptor {
+	snap := make([]PluginDescriptor, 0, len(a.plugins))
+	for id, rp := range a.plugins {
+		snap = append(snap, PluginDescriptor{ID: id, Cfg: rp.cfg})
+	}
+	return snap
+}
+
+// restore replaces the running set with exactly the plugins described by
+// snap: anything running that isn't in snap is stopped, anything in snap
+// that isn't running is started. The caller must hold a.mu.
+func (a *api) restore(snap []PluginDescriptor) error {
+	want := make(map[models.PluginID]PluginConfigCreate, len(snap))
+	for _, d := range snap {
+		want[d.ID] = d.Cfg
+	}
+
+	for id, rp := range a.plugins {
+		if _, ok := want[id]; !ok {
+			a.stopPlugin(rp)
+			delete(a.plugins, id)
+		}
+	}
+	for id, cfg := range want {
+		if _, ok := a.plugins[id]; ok {
+			continue
+		}
+		rp, err := a.startPlugin(cfg)
+		if err != nil {
+			return fmt.Errorf("restoring plugin %q: %w", id, err)
+		}
+		rp.id = id
+		a.plugins[id] = rp
+		a.beginMetricsReporting(rp)
+		a.notify(id, EventRunning, rp.state, nil)
+	}
+	return nil
+}
+
+// Apply applies req atomically: every create and delete succeeds, or the
+// running configuration is rolled back to its pre-request snapshot. Phase 1
+// validates every plugin to create and builds it through startPlugin, without
+// registering any of them; when dryRun is true, Apply returns after phase 1
+// for config linting. Plugins built in phase 1 are not stopped when Apply
+// returns early, on a dry run or a phase-1 failure. Phase 2 performs the
+// deletes and registers the built plugins, restoring the pre-commit snapshot
+// if anything in it fails.
+func (a *api) Apply(req ApplyRequest, dryRun bool) (ApplyResult, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	built := make([]*runningPlugin, len(req.Create))
+	for i, cfg := range req.Create {
+		fieldErrs, err := validatePluginConfigCreateValue(cfg)
+		if err != nil {
+			return ApplyResult{}, fmt.Errorf("validating create[%d]: %w", i, err)
+		}
+		if len(fieldErrs) > 0 {
+			for j := range fieldErrs {
+				fieldErrs[j].Field = fmt.Sprintf("create[%d].%s", i, fieldErrs[j].Field)
+			}
+			return ApplyResult{}, &ValidationError{Fields: fieldErrs}
+		}
+
+		rp, err := a.startPlugin(cfg)
+		if err != nil {
+			return ApplyResult{}, fmt.Errorf("validating create[%d] (%s): %w", i, cfg.Type, err)
+		}
+		built[i] = rp
+	}
+	for _, id := range req.Delete {
+		if _, ok := a.plugins[id]; !ok {
+			return ApplyResult{}, fmt.Errorf("%w: plugin %q", ErrNotFound, id)
+		}
+	}
+
+	result := ApplyResult{
+		Created: make([]ApplyItemResult, len(req.Create)),
+		Deleted: append([]models.PluginID(nil), req.Delete...),
+	}
+	for i, rp := range built {
+		result.Created[i] = ApplyItemResult{Type: rp.cfg.Type}
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	snap := a.snapshot()
+	if err := a.commit(built, req.Delete); err != nil {
+		if restoreErr := a.restore(snap); restoreErr != nil {
+			a.Log.Errorf("rolling back failed apply: %v", restoreErr)
+		}
+		return ApplyResult{}, err
+	}
+
+	for i, rp := range built {
+		result.Created[i].ID = rp.id
+		a.notify(rp.id, EventRunning, rp.state, nil)
+	}
+	for _, id := range req.Delete {
+		a.notify(id, EventStopped, PluginStopped, nil)
+	}
+	return result, nil
+}
+
+// commit performs the phase-2 mutations for Apply: it stops and removes the
+// deleted plugins and registers the already-built created ones. Any error
+// here leaves the running set in an inconsistent state that the caller must
+// restore from its pre-commit snapshot.
+func (a *api) commit(created []*runningPlugin, deleted []models.PluginID) error {
+	for _, id := range deleted {
+		rp, ok := a.plugins[id]
+		if !ok {
+			return fmt.Errorf("%w: plugin %q", ErrNotFound, id)
+		}
+		a.stopPlugin(rp)
+		delete(a.plugins, id)
+	}
+	for _, rp := range created {
+		id, err := newPluginID()
+		if err != nil {
+			return err
+		}
+		rp.id = id
+		a.plugins[id] = rp
+		a.beginMetricsReporting(rp)
+	}
+	return nil
+}
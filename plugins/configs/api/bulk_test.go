@@ -0,0 +1,105 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/models"
+	"github.com/stretchr/testify/require"
+)
+
+func validCreate(pluginType string) PluginConfigCreate {
+	return PluginConfigCreate{Type: pluginType, Config: map[string]interface{}{}}
+}
+
+// TestApplyRejectsUnknownDelete confirms Apply's phase-1 existence check
+// rejects a delete of a plugin that was never running, before anything is
+// ever mutated.
+func TestApplyRejectsUnknownDelete(t *testing.T) {
+	a := newAPI(testLogger{})
+	existingID, err := a.CreatePlugin(validCreate("cpu"), "")
+	require.NoError(t, err)
+
+	_, err = a.Apply(ApplyRequest{
+		Create: []PluginConfigCreate{validCreate("mem")},
+		Delete: []models.PluginID{"does-not-exist"},
+	}, false)
+	require.Error(t, err)
+
+	plugins := a.ListRunningPlugins()
+	require.Len(t, plugins, 1)
+	require.Equal(t, existingID, plugins[0].ID)
+	require.Equal(t, "cpu", plugins[0].Type)
+}
+
+// TestApplyRollsBackOnFailedCommit drives an actual failure inside commit()
+// itself (a duplicate ID in Delete passes the phase-1 existence check, since
+// that loop only checks existence, then fails on the second iteration inside
+// commit() once the first delete has already removed it), and confirms
+// restore() puts the running set back exactly as it was - including
+// re-publishing the EventRunning notification that every other path adding
+// to a.plugins (CreatePlugin, UpdatePlugin, Apply after commit) emits.
+func TestApplyRollsBackOnFailedCommit(t *testing.T) {
+	a := newAPI(testLogger{})
+	existingID, err := a.CreatePlugin(validCreate("cpu"), "")
+	require.NoError(t, err)
+
+	events, unsubscribe := a.SubscribeAll()
+	defer unsubscribe()
+
+	_, err = a.Apply(ApplyRequest{
+		Create: []PluginConfigCreate{validCreate("mem")},
+		Delete: []models.PluginID{existingID, existingID},
+	}, false)
+	require.Error(t, err)
+
+	plugins := a.ListRunningPlugins()
+	require.Len(t, plugins, 1)
+	require.Equal(t, existingID, plugins[0].ID)
+	require.Equal(t, "cpu", plugins[0].Type)
+
+	select {
+	case ev := <-events:
+		require.Equal(t, existingID, ev.ID)
+		require.Equal(t, EventRunning, ev.Type)
+	case <-time.After(time.Second):
+		t.Fatal("expected restore to publish an EventRunning notification for the restored plugin")
+	}
+}
+
+// TestApplyRejectsInvalidCreateEntry confirms a malformed create entry in a
+// bulk apply surfaces as a structured ValidationError, the same as the
+// single-item create/update routes, instead of a bare plugin_start_failed,
+// and leaves the running set untouched.
+func TestApplyRejectsInvalidCreateEntry(t *testing.T) {
+	a := newAPI(testLogger{})
+
+	_, err := a.Apply(ApplyRequest{
+		Create: []PluginConfigCreate{
+			validCreate("cpu"),
+			{Type: "", Config: map[string]interface{}{}},
+		},
+	}, false)
+	require.Error(t, err)
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.NotEmpty(t, verr.Fields)
+
+	require.Empty(t, a.ListRunningPlugins())
+}
+
+// TestApplyDryRunDoesNotMutate confirms dry_run validates and reports the
+// would-be result without registering anything.
+func TestApplyDryRunDoesNotMutate(t *testing.T) {
+	a := newAPI(testLogger{})
+
+	result, err := a.Apply(ApplyRequest{
+		Create: []PluginConfigCreate{validCreate("cpu")},
+	}, true)
+	require.NoError(t, err)
+	require.Len(t, result.Created, 1)
+	require.Empty(t, result.Created[0].ID)
+
+	require.Empty(t, a.ListRunningPlugins())
+}
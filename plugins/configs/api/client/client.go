@@ -0,0 +1,179 @@
+// Package client is a typed Go client for the telegraf config API described
+// by api/openapi.yaml, so external tools can call the service without
+// hand-rolling HTTP requests.
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Client calls a running ConfigAPIService instance.
+type Client struct {
+	BaseURL string
+	Token   string
+	HTTP    *http.Client
+}
+
+// New returns a Client targeting baseURL (e.g. "http://localhost:8080"),
+// with HTTP set to http.DefaultClient.
+func New(baseURL, token string) *Client {
+	return &Client{BaseURL: baseURL, Token: token, HTTP: http.DefaultClient}
+}
+
+// PluginConfigCreate mirrors api.PluginConfigCreate.
+type PluginConfigCreate struct {
+	Type   string                 `json:"type"`
+	Config map[string]interface{} `json:"config"`
+}
+
+// PluginTypeInfo mirrors api.PluginTypeInfo.
+type PluginTypeInfo struct {
+	Type string `json:"type"`
+}
+
+// RunningPluginInfo mirrors api.RunningPluginInfo.
+type RunningPluginInfo struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	State int    `json:"state"`
+}
+
+// Error is the structured error envelope returned by the config API.
+type Error struct {
+	Code      string        `json:"code"`
+	Message   string        `json:"message"`
+	RequestID string        `json:"request_id"`
+	Details   []interface{} `json:"details"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s (request_id=%s)", e.Code, e.Message, e.RequestID)
+}
+
+func (c *Client) do(method, path string, body, out interface{}) error {
+	var reqBody bytes.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		reqBody = *bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+path, &reqBody)
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var envelope struct {
+			Error Error `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&envelope); err == nil && envelope.Error.Code != "" {
+			return &envelope.Error
+		}
+		return fmt.Errorf("%s %s: unexpected status %d", method, path, resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// Status checks that the service is reachable.
+func (c *Client) Status() error {
+	return c.do(http.MethodGet, "/status", nil, nil)
+}
+
+// CreatePlugin starts a new plugin from cfg and returns its assigned ID.
+func (c *Client) CreatePlugin(cfg PluginConfigCreate) (string, error) {
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err := c.do(http.MethodPost, "/plugins/create", cfg, &resp); err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// UpdatePlugin hot-updates the running plugin identified by id.
+func (c *Client) UpdatePlugin(id string, cfg PluginConfigCreate) error {
+	return c.do(http.MethodPut, "/plugins/"+id, cfg, nil)
+}
+
+// DeletePlugin stops and removes the running plugin identified by id.
+func (c *Client) DeletePlugin(id string) error {
+	return c.do(http.MethodDelete, "/plugins/"+id, nil, nil)
+}
+
+// ListPluginTypes lists the plugin types available to create.
+func (c *Client) ListPluginTypes() ([]PluginTypeInfo, error) {
+	var resp []PluginTypeInfo
+	err := c.do(http.MethodGet, "/plugins/list", nil, &resp)
+	return resp, err
+}
+
+// ListRunningPlugins lists the currently running plugin instances.
+func (c *Client) ListRunningPlugins() ([]RunningPluginInfo, error) {
+	var resp []RunningPluginInfo
+	err := c.do(http.MethodGet, "/plugins/running", nil, &resp)
+	return resp, err
+}
+
+// PluginStatus returns the lifecycle state string of the plugin identified
+// by id.
+func (c *Client) PluginStatus(id string) (string, error) {
+	var resp struct {
+		Status string `json:"status"`
+	}
+	if err := c.do(http.MethodGet, "/plugins/"+id+"/status", nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.Status, nil
+}
+
+// ApplyItemResult mirrors api.ApplyItemResult.
+type ApplyItemResult struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type"`
+}
+
+// ApplyResult mirrors api.ApplyResult.
+type ApplyResult struct {
+	Created []ApplyItemResult `json:"created"`
+	Deleted []string          `json:"deleted"`
+}
+
+// Apply creates and deletes plugins in one transactional request. When
+// dryRun is true, the server only constructs and validates the creates
+// without mutating the running set.
+func (c *Client) Apply(create []PluginConfigCreate, deleteIDs []string, dryRun bool) (*ApplyResult, error) {
+	path := "/plugins/apply"
+	if dryRun {
+		path += "?dry_run=true"
+	}
+	body := struct {
+		Create []PluginConfigCreate `json:"create"`
+		Delete []string             `json:"delete"`
+	}{Create: create, Delete: deleteIDs}
+
+	var resp ApplyResult
+	if err := c.do(http.MethodPost, path, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
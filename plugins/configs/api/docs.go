@@ -0,0 +1,91 @@
+package api
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"gopkg.in/yaml.v2"
+)
+
+//go:embed openapi.yaml
+var openapiYAML []byte
+
+// openapiJSON is the embedded spec converted to JSON once at package init,
+// since it never changes at runtime.
+var openapiJSON = mustYAMLToJSON(openapiYAML)
+
+func mustYAMLToJSON(raw []byte) []byte {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		panic("api: invalid embedded openapi.yaml: " + err.Error())
+	}
+	out, err := json.Marshal(convertMapKeys(doc))
+	if err != nil {
+		panic("api: marshaling openapi spec: " + err.Error())
+	}
+	return out
+}
+
+// convertMapKeys recursively converts the map[interface{}]interface{} values
+// produced by yaml.v2 into map[string]interface{}, which encoding/json can
+// marshal.
+func convertMapKeys(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			out[fmt.Sprint(k)] = convertMapKeys(val)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			out[k] = convertMapKeys(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = convertMapKeys(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// openapiSpec serves the embedded OpenAPI 3 document as JSON.
+func (s *ConfigAPIService) openapiSpec(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(openapiJSON); err != nil {
+		s.Log.Errorf("writing openapi spec: %v", err)
+	}
+}
+
+// docs serves a minimal Swagger UI page pointed at /openapi.json.
+func (s *ConfigAPIService) docs(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := w.Write([]byte(swaggerUIPage)); err != nil {
+		s.Log.Errorf("writing docs page: %v", err)
+	}
+}
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Telegraf Config API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => SwaggerUIBundle({url: '/openapi.json', dom_id: '#swagger-ui'})
+  </script>
+</body>
+</html>
+`
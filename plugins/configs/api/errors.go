@@ -0,0 +1,35 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	ClientErr           = errors.New("error")
+	ErrBadRequest       = fmt.Errorf("%w bad request", ClientErr)
+	ErrNotFound         = fmt.Errorf("%w not found", ClientErr)
+	ErrDuplicatePlugin  = fmt.Errorf("%w duplicate plugin id", ClientErr)
+	ErrPluginNotStarted = fmt.Errorf("%w plugin failed to start", ClientErr)
+	ErrUnauthorized     = fmt.Errorf("%w unauthorized", ClientErr)
+)
+
+// errorCode maps a sentinel error to the machine-readable code returned to
+// clients in the error envelope, and the HTTP status it is rendered with.
+func errorCode(err error) (code string, status int) {
+	switch {
+	case errors.Is(err, ErrUnauthorized):
+		return "unauthorized", http.StatusUnauthorized
+	case errors.Is(err, ErrDuplicatePlugin):
+		return "duplicate_id", http.StatusConflict
+	case errors.Is(err, ErrPluginNotStarted):
+		return "plugin_start_failed", http.StatusUnprocessableEntity
+	case errors.Is(err, ErrNotFound):
+		return "not_found", http.StatusNotFound
+	case errors.Is(err, ErrBadRequest):
+		return "bad_request", http.StatusBadRequest
+	default:
+		return "internal_error", http.StatusInternalServerError
+	}
+}
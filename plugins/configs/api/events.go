@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/influxdata/telegraf/models"
+)
+
+// metricsReportInterval is how often a running plugin's metrics count is
+// snapshotted and published as an EventMetricsReport.
+const metricsReportInterval = 10 * time.Second
+
+// PluginEventType enumerates the lifecycle transitions and other notable
+// occurrences that subscribers receive from api.Subscribe.
+type PluginEventType string
+
+const (
+	EventCreated       PluginEventType = "created"
+	EventStarting      PluginEventType = "starting"
+	EventRunning       PluginEventType = "running"
+	EventFailed        PluginEventType = "failed"
+	EventStopped       PluginEventType = "stopped"
+	EventMetricsReport PluginEventType = "metrics"
+)
+
+// PluginEvent is a single lifecycle transition, error, or metrics snapshot
+// pushed to subscribers registered via api.Subscribe/SubscribeAll.
+type PluginEvent struct {
+	ID        models.PluginID `json:"id"`
+	Type      PluginEventType `json:"type"`
+	State     PluginState     `json:"state"`
+	Error     string          `json:"error,omitempty"`
+	Metrics   int             `json:"metrics,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// subscriber pairs an event channel with the plugin ID it is scoped to; an
+// empty id means "every plugin".
+type subscriber struct {
+	id models.PluginID
+	ch chan PluginEvent
+}
+
+// broker fans plugin lifecycle events out to subscribed channels. Subscribe
+// is safe to call concurrently with publish.
+type broker struct {
+	mu   sync.Mutex
+	subs map[*subscriber]struct{}
+}
+
+func newBroker() *broker {
+	return &broker{subs: make(map[*subscriber]struct{})}
+}
+
+// subscribe registers a new listener for events on id, or on every plugin
+// when id is empty. The returned unsubscribe function must be called
+// exactly once to release the channel.
+func (b *broker) subscribe(id models.PluginID) (<-chan PluginEvent, func()) {
+	sub := &subscriber{id: id, ch: make(chan PluginEvent, 16)}
+
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		if _, ok := b.subs[sub]; ok {
+			delete(b.subs, sub)
+			close(sub.ch)
+		}
+		b.mu.Unlock()
+	}
+	return sub.ch, unsubscribe
+}
+
+// publish fans ev out to every subscriber listening to ev.ID plus every
+// global subscriber. A subscriber whose buffer is full is skipped rather
+// than blocking the publisher.
+func (b *broker) publish(ev PluginEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subs {
+		if sub.id != "" && sub.id != ev.ID {
+			continue
+		}
+		select {
+		case sub.ch <- ev:
+		default:
+		}
+	}
+}
+
+// Subscribe registers a listener for lifecycle events on a single plugin.
+// Call the returned function to unsubscribe once the caller is done.
+func (a *api) Subscribe(id models.PluginID) (<-chan PluginEvent, func()) {
+	return a.events.subscribe(id)
+}
+
+// SubscribeAll registers a listener for lifecycle events across every
+// plugin. Call the returned function to unsubscribe once the caller is done.
+func (a *api) SubscribeAll() (<-chan PluginEvent, func()) {
+	return a.events.subscribe("")
+}
+
+// notify publishes a lifecycle transition for id.
+func (a *api) notify(id models.PluginID, typ PluginEventType, state PluginState, err error) {
+	ev := PluginEvent{ID: id, Type: typ, State: state, Timestamp: time.Now()}
+	if err != nil {
+		ev.Error = err.Error()
+	}
+	a.events.publish(ev)
+}
+
+// beginMetricsReporting starts a goroutine that publishes an
+// EventMetricsReport snapshot for rp every metricsReportInterval, until
+// rp.cancelMetrics is called (by stopPlugin). rp.id must already be set.
+func (a *api) beginMetricsReporting(rp *runningPlugin) {
+	ctx, cancel := context.WithCancel(context.Background())
+	rp.cancelMetrics = cancel
+
+	go func() {
+		ticker := time.NewTicker(metricsReportInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				a.events.publish(PluginEvent{
+					ID:        rp.id,
+					Type:      EventMetricsReport,
+					State:     rp.state,
+					Metrics:   int(atomic.LoadInt64(&rp.metrics)),
+					Timestamp: time.Now(),
+				})
+			}
+		}
+	}()
+}
@@ -3,8 +3,8 @@ package api
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"io"
 	"log" // nolint:revive
 	"net/http"
 	"time"
@@ -14,16 +14,13 @@ import (
 	"github.com/influxdata/telegraf/models"
 )
 
-var (
-	ClientErr     = errors.New("error")
-	ErrBadRequest = fmt.Errorf("%w bad request", ClientErr)
-	ErrNotFound   = fmt.Errorf("%w not found", ClientErr)
-)
-
 type ConfigAPIService struct {
 	server *http.Server
 	api    *api
 	Log    telegraf.Logger
+	// Auth configures how requests are authenticated and scoped. A zero
+	// value accepts every request as an anonymous admin.
+	Auth *AuthConfig
 }
 
 func newConfigAPIService(server *http.Server, api *api, logger telegraf.Logger) *ConfigAPIService {
@@ -31,20 +28,26 @@ func newConfigAPIService(server *http.Server, api *api, logger telegraf.Logger)
 		server: server,
 		api:    api,
 		Log:    logger,
+		Auth:   &AuthConfig{},
 	}
-	server.Handler = service.mux()
+	server.Handler = requestIDMiddleware(service.mux())
 	return service
 }
 
 // nolint:revive
 func (s *ConfigAPIService) mux() *mux.Router {
 	m := mux.NewRouter()
-	m.HandleFunc("/status", s.status).Methods("GET")
-	m.HandleFunc("/plugins/create", s.createPlugin).Methods("POST")
-	m.HandleFunc("/plugins/{id:[0-9a-f]+}/status", s.pluginStatus).Methods("GET")
-	m.HandleFunc("/plugins/list", s.listPlugins).Methods("GET")
-	m.HandleFunc("/plugins/running", s.runningPlugins).Methods("GET")
-	m.HandleFunc("/plugins/{id:[0-9a-f]+}", s.deleteOrUpdatePlugin).Methods("DELETE", "PUT")
+	m.HandleFunc("/status", s.requireScope(ScopeRead, "/status", s.status)).Methods("GET")
+	m.HandleFunc("/plugins/create", s.requireScope(ScopeWrite, "/plugins/create", s.createPlugin)).Methods("POST")
+	m.HandleFunc("/plugins/{id:[0-9a-f]+}/status", s.requireScope(ScopeRead, "/plugins/{id}/status", s.pluginStatus)).Methods("GET")
+	m.HandleFunc("/plugins/{id:[0-9a-f]+}/events", s.requireScope(ScopeRead, "/plugins/{id}/events", s.pluginEvents)).Methods("GET")
+	m.HandleFunc("/plugins/list", s.requireScope(ScopeRead, "/plugins/list", s.listPlugins)).Methods("GET")
+	m.HandleFunc("/plugins/running", s.requireScope(ScopeRead, "/plugins/running", s.runningPlugins)).Methods("GET")
+	m.HandleFunc("/plugins/{id:[0-9a-f]+}", s.requireScope(ScopeWrite, "/plugins/{id}", s.deleteOrUpdatePlugin)).Methods("DELETE", "PUT")
+	m.HandleFunc("/plugins/apply", s.requireScope(ScopeWrite, "/plugins/apply", s.applyPlugins)).Methods("POST")
+	m.HandleFunc("/events", s.requireScope(ScopeRead, "/events", s.allEvents)).Methods("GET")
+	m.HandleFunc("/openapi.json", s.openapiSpec).Methods("GET")
+	m.HandleFunc("/docs", s.docs).Methods("GET")
 	return m
 }
 
@@ -52,49 +55,39 @@ func (s *ConfigAPIService) status(w http.ResponseWriter, req *http.Request) {
 	if req.Body != nil {
 		defer req.Body.Close()
 	}
-	_, err := w.Write([]byte("ok"))
-	if err != nil {
-		log.Printf("W! error writing to connection: %v", err)
-		return
-	}
+	writeJSON(w, s.Log, http.StatusOK, map[string]string{"status": "ok"})
 }
 
 func (s *ConfigAPIService) createPlugin(w http.ResponseWriter, req *http.Request) {
 	if req.Body != nil {
 		defer req.Body.Close()
 	}
-	cfg := PluginConfigCreate{}
-
-	dec := json.NewDecoder(req.Body)
-	if err := dec.Decode(&cfg); err != nil {
-		s.renderError(fmt.Errorf("%w: decode failed %v", ErrBadRequest, err), w)
-		return
-	}
-	id, err := s.api.CreatePlugin(cfg, "")
+	body, err := io.ReadAll(req.Body)
 	if err != nil {
-		s.renderError(err, w)
+		s.writeError(w, req, fmt.Errorf("%w: reading body %v", ErrBadRequest, err))
 		return
 	}
-	w.Header().Set("Content-Type", "application/json")
-	_, err = w.Write([]byte(fmt.Sprintf(`{"id": "%s"}`, id)))
-	if err != nil {
-		log.Printf("W! error writing to connection: %v", err)
+	if fieldErrs, err := validatePluginConfigCreate(body); err != nil {
+		s.writeError(w, req, err)
+		return
+	} else if len(fieldErrs) > 0 {
+		s.writeError(w, req, &ValidationError{Fields: fieldErrs})
 		return
 	}
-}
 
-func (s *ConfigAPIService) renderError(err error, w http.ResponseWriter) {
-	if errors.Is(err, ErrBadRequest) {
-		s.Log.Error(err)
-		w.WriteHeader(http.StatusBadRequest)
+	cfg := PluginConfigCreate{}
+	if err := json.Unmarshal(body, &cfg); err != nil {
+		s.writeError(w, req, fmt.Errorf("%w: decode failed %v", ErrBadRequest, err))
 		return
-	} else if errors.Is(err, ErrNotFound) {
-		s.Log.Error(err)
-		w.WriteHeader(http.StatusNotFound)
+	}
+	id, err := s.api.CreatePlugin(cfg, "")
+	if err != nil {
+		s.audit("/plugins/create", "", req, "error")
+		s.writeError(w, req, err)
 		return
 	}
-	s.Log.Error(err)
-	w.WriteHeader(http.StatusInternalServerError)
+	s.audit("/plugins/create", string(id), req, "ok")
+	writeJSON(w, s.Log, http.StatusOK, map[string]string{"id": string(id)})
 }
 
 func (s *ConfigAPIService) Start() {
@@ -106,51 +99,24 @@ func (s *ConfigAPIService) Start() {
 func (s *ConfigAPIService) listPlugins(w http.ResponseWriter, req *http.Request) {
 	defer req.Body.Close()
 	typeInfo := s.api.ListPluginTypes()
-
-	bytes, err := json.Marshal(typeInfo)
-	if err != nil {
-		s.renderError(fmt.Errorf("marshal failed %w", err), w)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json")
-	_, err = w.Write(bytes)
-	if err != nil {
-		log.Printf("W! error writing to connection: %v", err)
-		return
-	}
+	writeJSON(w, s.Log, http.StatusOK, typeInfo)
 }
 
 func (s *ConfigAPIService) runningPlugins(w http.ResponseWriter, req *http.Request) {
 	defer req.Body.Close()
 	plugins := s.api.ListRunningPlugins()
-
-	bytes, err := json.Marshal(plugins)
-	if err != nil {
-		s.renderError(fmt.Errorf("marshal failed %w", err), w)
-		return
-	}
-	w.Header().Set("Content-Type", "application/json")
-	_, err = w.Write(bytes)
-	if err != nil {
-		log.Printf("W! error writing to connection: %v", err)
-		return
-	}
+	writeJSON(w, s.Log, http.StatusOK, plugins)
 }
 
 func (s *ConfigAPIService) pluginStatus(w http.ResponseWriter, req *http.Request) {
 	defer req.Body.Close()
 	id := mux.Vars(req)["id"]
 	if len(id) == 0 {
-		w.WriteHeader(http.StatusBadRequest)
+		s.writeError(w, req, fmt.Errorf("%w: missing plugin id", ErrBadRequest))
 		return
 	}
 	state := s.api.GetPluginStatus(models.PluginID(id))
-	w.Header().Set("Content-Type", "application/json")
-	_, err := w.Write([]byte(fmt.Sprintf(`{"status": %q}`, state.String())))
-	if err != nil {
-		log.Printf("W! error writing to connection: %v", err)
-		return
-	}
+	writeJSON(w, s.Log, http.StatusOK, map[string]string{"status": state.String()})
 }
 
 func (s *ConfigAPIService) Stop() {
@@ -168,7 +134,7 @@ func (s *ConfigAPIService) deleteOrUpdatePlugin(w http.ResponseWriter, req *http
 	case "PUT":
 		s.updatePlugin(w, req)
 	default:
-		w.WriteHeader(http.StatusBadRequest)
+		s.writeError(w, req, fmt.Errorf("%w: method %s not supported", ErrBadRequest, req.Method))
 	}
 }
 
@@ -176,15 +142,50 @@ func (s *ConfigAPIService) deletePlugin(w http.ResponseWriter, req *http.Request
 	defer req.Body.Close()
 	id := mux.Vars(req)["id"]
 	if len(id) == 0 {
-		w.WriteHeader(http.StatusBadRequest)
+		s.writeError(w, req, fmt.Errorf("%w: missing plugin id", ErrBadRequest))
 		return
 	}
 	if err := s.api.DeletePlugin(models.PluginID(id)); err != nil {
-		s.renderError(fmt.Errorf("delete plugin %w", err), w)
+		s.audit("/plugins/{id}", id, req, "error")
+		s.writeError(w, req, fmt.Errorf("delete plugin %w", err))
+		return
 	}
-	w.WriteHeader(http.StatusOK)
+	s.audit("/plugins/{id}", id, req, "ok")
+	writeJSON(w, s.Log, http.StatusOK, map[string]string{"id": id})
 }
 
 func (s *ConfigAPIService) updatePlugin(w http.ResponseWriter, req *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
+	defer req.Body.Close()
+	id := mux.Vars(req)["id"]
+	if len(id) == 0 {
+		s.writeError(w, req, fmt.Errorf("%w: missing plugin id", ErrBadRequest))
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		s.writeError(w, req, fmt.Errorf("%w: reading body %v", ErrBadRequest, err))
+		return
+	}
+	if fieldErrs, err := validatePluginConfigCreate(body); err != nil {
+		s.writeError(w, req, err)
+		return
+	} else if len(fieldErrs) > 0 {
+		s.writeError(w, req, &ValidationError{Fields: fieldErrs})
+		return
+	}
+
+	cfg := PluginConfigCreate{}
+	if err := json.Unmarshal(body, &cfg); err != nil {
+		s.writeError(w, req, fmt.Errorf("%w: decode failed %v", ErrBadRequest, err))
+		return
+	}
+
+	if err := s.api.UpdatePlugin(models.PluginID(id), cfg); err != nil {
+		s.audit("/plugins/{id}", id, req, "error")
+		s.writeError(w, req, fmt.Errorf("update plugin %w", err))
+		return
+	}
+	s.audit("/plugins/{id}", id, req, "ok")
+	writeJSON(w, s.Log, http.StatusOK, map[string]string{"id": id})
 }
\ No newline at end of file
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+type requestIDKey struct{}
+
+// newRequestID returns a new RFC 4122 UUID to identify a single request.
+func newRequestID() string {
+	return uuid.New().String()
+}
+
+func requestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// requestIDMiddleware assigns every request a unique ID, echoes it in the
+// X-Request-ID response header, and makes it available to handlers via
+// requestIDFromContext for logging and error envelopes.
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id := newRequestID()
+		w.Header().Set("X-Request-ID", id)
+		ctx := context.WithValue(req.Context(), requestIDKey{}, id)
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
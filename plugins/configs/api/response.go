@@ -0,0 +1,68 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/influxdata/telegraf"
+)
+
+// errorDetail is a single machine-readable fact about why a request failed;
+// for validation failures these are the offending fields.
+type errorDetail struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// errorBody is the JSON shape writeError writes for every error it renders.
+type errorBody struct {
+	Error struct {
+		Code      string        `json:"code"`
+		Message   string        `json:"message"`
+		RequestID string        `json:"request_id"`
+		Details   []errorDetail `json:"details,omitempty"`
+	} `json:"error"`
+}
+
+// writeJSON marshals body, sets the JSON content type, and writes status,
+// logging rather than panicking if the connection is already gone. Every
+// plugin and status handler response, success or error, goes through this so
+// content-type and shape stay uniform; the /openapi.json and /docs handlers
+// and the event streams write their bodies directly.
+func writeJSON(w http.ResponseWriter, log telegraf.Logger, status int, body interface{}) {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(encoded); err != nil {
+		log.Warnf("error writing to connection: %v", err)
+	}
+}
+
+// writeError renders err as the standard JSON error envelope, tagged with
+// the request's ID, and logs it server-side with that same ID so the two
+// can be correlated.
+func (s *ConfigAPIService) writeError(w http.ResponseWriter, req *http.Request, err error) {
+	code, status := errorCode(err)
+	requestID := requestIDFromContext(req.Context())
+
+	body := errorBody{}
+	body.Error.Code = code
+	body.Error.Message = err.Error()
+	body.Error.RequestID = requestID
+
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		body.Error.Details = make([]errorDetail, 0, len(verr.Fields))
+		for _, f := range verr.Fields {
+			body.Error.Details = append(body.Error.Details, errorDetail{Field: f.Field, Message: f.Message})
+		}
+	}
+
+	s.Log.Errorf("request_id=%s code=%s error=%v", requestID, code, err)
+	writeJSON(w, s.Log, status, body)
+}
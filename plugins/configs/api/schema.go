@@ -0,0 +1,65 @@
+package api
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+//go:embed plugin_config_create.schema.json
+var pluginConfigCreateSchemaJSON []byte
+
+var pluginConfigCreateSchema = gojsonschema.NewBytesLoader(pluginConfigCreateSchemaJSON)
+
+// FieldError describes a single JSON-schema validation failure, identifying
+// the offending field so clients can render it without parsing prose.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError wraps ErrBadRequest with the field-level schema violations
+// that caused it, so handlers can report more than a bare status code.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%v: %d field error(s)", ErrBadRequest, len(e.Fields))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrBadRequest
+}
+
+// validatePluginConfigCreate validates raw request body bytes against the
+// PluginConfigCreate JSON schema before it is ever decoded into Go types and
+// handed to api.CreatePlugin/UpdatePlugin.
+func validatePluginConfigCreate(body []byte) ([]FieldError, error) {
+	result, err := gojsonschema.Validate(pluginConfigCreateSchema, gojsonschema.NewBytesLoader(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	if result.Valid() {
+		return nil, nil
+	}
+
+	fieldErrs := make([]FieldError, 0, len(result.Errors()))
+	for _, re := range result.Errors() {
+		fieldErrs = append(fieldErrs, FieldError{Field: re.Field(), Message: re.Description()})
+	}
+	return fieldErrs, nil
+}
+
+// validatePluginConfigCreateValue re-encodes cfg and validates it against the
+// same schema as validatePluginConfigCreate, for callers (such as bulk apply)
+// that already hold a decoded PluginConfigCreate rather than raw body bytes.
+func validatePluginConfigCreateValue(cfg PluginConfigCreate) ([]FieldError, error) {
+	body, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: encoding %v", ErrBadRequest, err)
+	}
+	return validatePluginConfigCreate(body)
+}
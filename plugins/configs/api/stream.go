@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/influxdata/telegraf/models"
+)
+
+const heartbeatInterval = 15 * time.Second
+
+var upgrader = websocket.Upgrader{
+	// Config API clients are expected to be operator tooling hitting this
+	// service directly, not browsers subject to CORS; any origin is fine.
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+// pluginEvents streams lifecycle transitions for a single plugin as
+// Server-Sent Events, or upgrades to a WebSocket when the client requests
+// it via the Upgrade header.
+func (s *ConfigAPIService) pluginEvents(w http.ResponseWriter, req *http.Request) {
+	id := models.PluginID(mux.Vars(req)["id"])
+	if len(id) == 0 {
+		s.writeError(w, req, fmt.Errorf("%w: missing plugin id", ErrBadRequest))
+		return
+	}
+
+	events, unsubscribe := s.api.Subscribe(id)
+	defer unsubscribe()
+
+	if websocket.IsWebSocketUpgrade(req) {
+		s.streamWebSocket(w, req, events)
+		return
+	}
+	s.streamSSE(w, req, events)
+}
+
+// allEvents streams lifecycle transitions across every plugin, optionally
+// filtered to a comma-separated set of event types via `types=`.
+func (s *ConfigAPIService) allEvents(w http.ResponseWriter, req *http.Request) {
+	types := parseTypesFilter(req.URL.Query().Get("types"))
+
+	events, unsubscribe := s.api.SubscribeAll()
+	defer unsubscribe()
+
+	filtered := make(chan PluginEvent)
+	done := req.Context().Done()
+	go func() {
+		defer close(filtered)
+		for {
+			select {
+			case <-done:
+				return
+			case ev, ok := <-events:
+				if !ok {
+					return
+				}
+				if len(types) > 0 && !types[ev.Type] {
+					continue
+				}
+				select {
+				case filtered <- ev:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+
+	if websocket.IsWebSocketUpgrade(req) {
+		s.streamWebSocket(w, req, filtered)
+		return
+	}
+	s.streamSSE(w, req, filtered)
+}
+
+func parseTypesFilter(raw string) map[PluginEventType]bool {
+	if raw == "" {
+		return nil
+	}
+	types := make(map[PluginEventType]bool)
+	for _, t := range strings.Split(raw, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types[PluginEventType(t)] = true
+		}
+	}
+	return types
+}
+
+// streamSSE writes events to w as they arrive, sending a heartbeat comment
+// every heartbeatInterval to keep intermediaries from closing the
+// connection, until the client disconnects.
+func (s *ConfigAPIService) streamSSE(w http.ResponseWriter, req *http.Request, events <-chan PluginEvent) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.writeError(w, req, fmt.Errorf("%w: streaming not supported by the underlying connection", ClientErr))
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			payload, err := json.Marshal(ev)
+			if err != nil {
+				s.Log.Errorf("marshaling event: %v", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", payload); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// streamWebSocket upgrades the connection and writes events as JSON text
+// frames, with the same heartbeat cadence as streamSSE, until the client
+// disconnects or the upgraded connection errors.
+func (s *ConfigAPIService) streamWebSocket(w http.ResponseWriter, req *http.Request, events <-chan PluginEvent) {
+	conn, err := upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		s.Log.Errorf("upgrading websocket: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case <-heartbeat.C:
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(ev); err != nil {
+				return
+			}
+		}
+	}
+}